@@ -2,7 +2,6 @@ package command
 
 import (
 	"github.com/mattermost/mattermost-server/model"
-	"github.com/techbot/server/techbuzz"
 )
 
 func commandGetConfig() *Config {
@@ -13,18 +12,13 @@ func commandGetConfig() *Config {
 			AutoComplete:     true,
 		},
 		HelpText: "",
-		Validate: validateGetConfig,
+		Validate: validateSubscribed,
 		Execute:  getConfig,
 	}
 }
 
-func validateGetConfig(args []string, context Context) (*model.CommandResponse, *model.AppError) {
-	return nil, nil
-}
-
-func getConfig(args []string, context Context) (*model.CommandResponse, *model.AppError) {
-	userID := context.CommandArgs.UserId
-	config := techbuzz.GetUserConfig(userID)
+func getConfig(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
+	config := context.UserConfig
 	if config.Enabled ==false {
 		return &model.CommandResponse{
 			Type: model.COMMAND_RESPONSE_TYPE_EPHEMERAL,
@@ -2,26 +2,115 @@ package command
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/techbot/server/config"
+	"github.com/techbot/server/techbuzz"
+	"github.com/techbot/server/util"
 )
 
+// Context carries the command arguments along with the values resolved by the validators for use by the executors.
 type Context struct {
 	CommandArgs *model.CommandArgs
-	Props       map[string]interface{}
+
+	// RawArgs is the command text left after the arguments that have been split so far, exactly as typed
+	RawArgs string
+
+	SubCommand     *Config
+	SubCommandArgs []string
+
+	UserConfig *techbuzz.UserConfig
+
+	Tags         []string
+	TagsNotFound []string
+
+	Tag      string
+	Question string
+
+	// Failed is set by validators and executors that reply with a failure message rather than an AppError,
+	// so that the execution isn't logged as successful.
+	Failed bool
 }
 
 type Config struct {
 	Command  *model.Command
 	HelpText string
-	Execute  func([]string, Context) (*model.CommandResponse, *model.AppError)
-	Validate func([]string, Context) (*model.CommandResponse, *model.AppError)
+	// ArgsLimit is the number of leading arguments split for the command, the rest being left in Context.RawArgs.
+	// Zero splits all arguments.
+	ArgsLimit int
+	Execute   func([]string, *Context) (*model.CommandResponse, *model.AppError)
+	Validate  func([]string, *Context) (*model.CommandResponse, *model.AppError)
 }
 
 func (c *Config) Syntax() string {
 	return fmt.Sprintf("/%s %s", c.Command.Trigger, c.Command.AutoCompleteHint)
 }
 
+// validateAll runs validators in order, stopping at the first one that responds or errors.
+func validateAll(validators ...func([]string, *Context) (*model.CommandResponse, *model.AppError)) func([]string, *Context) (*model.CommandResponse, *model.AppError) {
+	return func(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
+		for _, validator := range validators {
+			if response, appErr := validator(args, context); response != nil || appErr != nil {
+				return response, appErr
+			}
+		}
+		return nil, nil
+	}
+}
+
+// sendFailure replies with msg, marking the execution as failed.
+func sendFailure(context *Context, msg string) (*model.CommandResponse, *model.AppError) {
+	context.Failed = true
+	return util.SendEphemeralText(msg)
+}
+
+// validateSystemAdmin rejects callers who aren't system admins.
+func validateSystemAdmin(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
+	if !config.Mattermost.HasPermissionTo(context.CommandArgs.UserId, model.PERMISSION_MANAGE_SYSTEM) {
+		return util.SendEphemeralText("You need to be a System Administrator to run this command.")
+	}
+	return nil, nil
+}
+
+// validateArgsCount returns a validator rejecting calls with fewer than count arguments.
+func validateArgsCount(count int) func([]string, *Context) (*model.CommandResponse, *model.AppError) {
+	return func(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
+		if len(args) < count {
+			return util.SendEphemeralText(fmt.Sprintf("Expected %d arguments but got %d.", count, len(args)))
+		}
+		return nil, nil
+	}
+}
+
+// validateSubscribed loads the caller's config into the context, rejecting callers who have never subscribed.
+func validateSubscribed(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
+	userConfig := techbuzz.GetUserConfig(context.CommandArgs.UserId)
+	if userConfig == nil {
+		return util.SendEphemeralText("You have not subscribed yet.")
+	}
+
+	context.UserConfig = userConfig
+	return nil, nil
+}
+
+// validateTags splits the arguments into known tags and unknown ones.
+func validateTags(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
+	tagsNotFound := []string{}
+	tags := []string{}
+	for _, arg := range args {
+		if techbuzz.TechTag[strings.ToLower(arg)] {
+			tags = append(tags, strings.ToLower(arg))
+		} else {
+			tagsNotFound = append(tagsNotFound, strings.ToLower(arg))
+		}
+	}
+	context.Tags = tags
+	context.TagsNotFound = tagsNotFound
+
+	return nil, nil
+}
+
 var commands = map[string]*Config{
 	commandSubscribeTopics().Command.Trigger:   commandSubscribeTopics(),
 	commandUnsubscribeTopics().Command.Trigger: commandUnsubscribeTopics(),
@@ -9,11 +9,11 @@ import (
 )
 
 // Master is the driver command for all other commands
-// All other slash commands are run as /standup <command-name> [command-args]
+// All other slash commands are run as /<trigger> <command-name> [command-args], where trigger is configurable
 func Master() *Config {
 	return &Config{
 		Command: &model.Command{
-			Trigger:          config.CommandPrefix,
+			Trigger:          config.GetConfig().CommandTrigger,
 			AutoComplete:     true,
 			AutoCompleteDesc: "Available commands: " + strings.Join(getAvailableCommands(), ", "),
 		},
@@ -31,7 +31,7 @@ func getAvailableCommands() []string {
 	return availableCommands
 }
 
-func validateCommandMaster(args []string, context Context) (*model.CommandResponse, *model.AppError) {
+func validateCommandMaster(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
 	// validate that a command is specified
 	if len(args) == 0 {
 		return util.SendEphemeralText("Please specify a command")
@@ -45,12 +45,18 @@ func validateCommandMaster(args []string, context Context) (*model.CommandRespon
 		return util.SendEphemeralText("Invalid command: " + subCommand)
 	}
 
-	// add sub-command in props so we don't need to extract it again
-	context.Props["subCommand"] = subCommandCommand
-	context.Props["subCommandArgs"] = args[1:]
+	subCommandArgs, rawArgs, err := util.SplitArgsN(context.RawArgs, subCommandCommand.ArgsLimit)
+	if err != nil {
+		return util.SendEphemeralText(err.Error())
+	}
+
+	// add sub-command in context so we don't need to extract it again
+	context.SubCommand = subCommandCommand
+	context.SubCommandArgs = subCommandArgs
+	context.RawArgs = rawArgs
 
 	// run validation for sub-command
-	if response, appErr := subCommandCommand.Validate(args[1:], context); response != nil || appErr != nil {
+	if response, appErr := subCommandCommand.Validate(subCommandArgs, context); response != nil || appErr != nil {
 		return response, appErr
 	}
 
@@ -58,8 +64,6 @@ func validateCommandMaster(args []string, context Context) (*model.CommandRespon
 	return nil, nil
 }
 
-func executeCommandMaster(args []string, context Context) (*model.CommandResponse, *model.AppError) {
-	subCommand := context.Props["subCommand"].(*Config)
-	subCommandArgs := context.Props["subCommandArgs"].([]string)
-	return subCommand.Execute(subCommandArgs, context)
+func executeCommandMaster(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
+	return context.SubCommand.Execute(context.SubCommandArgs, context)
 }
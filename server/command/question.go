@@ -1,7 +1,10 @@
 package command
 
 import (
+	"strings"
+
 	"github.com/mattermost/mattermost-server/model"
+	"github.com/techbot/server/config"
 	"github.com/techbot/server/techbuzz"
 	"github.com/techbot/server/util"
 )
@@ -12,31 +15,48 @@ func commandAskQuestion() *Config {
 			Trigger:          "question",
 			AutoComplete:     true,
 			AutoCompleteDesc: "Ask a question.",
+			AutoCompleteHint: "<tag> <question>",
 		},
 		HelpText: "",
-		Validate: validatequestion,
-		Execute:  askQuestion,
+		// only the tag is split, the question is taken exactly as typed
+		ArgsLimit: 1,
+		Validate:  validatequestion,
+		Execute:   askQuestion,
 	}
 }
 
-func validatequestion(args []string, context Context) (*model.CommandResponse, *model.AppError) {
-	if len(args) < 2 {
+func validatequestion(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
+	question := strings.TrimSpace(util.TrimEnclosingQuotes(strings.TrimSpace(context.RawArgs)))
+	if len(args) == 0 || question == "" {
 		return util.SendEphemeralText("Please specify both tag and question")
 	}
 
-	context.Props["tag"] = args[0]
-	context.Props["question"] = args[1]
+	context.Tag = args[0]
+	context.Question = question
 
 	return nil, nil
 }
 
-func askQuestion(args []string, context Context) (*model.CommandResponse, *model.AppError) {
+func askQuestion(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
 	userID := context.CommandArgs.UserId
-	tag := context.Props["tag"].(string)
-	question := context.Props["question"].(string)
-	questionID := techbuzz.AddQuestion(question)
-	memberIDs := techbuzz.GetTagMemberIDs(tag)
-	techbuzz.PostQuestion(memberIDs, question, userID, questionID)
+	tag := context.Tag
+	question := context.Question
+	questionID, err := techbuzz.AddQuestion(question)
+	if err != nil {
+		config.Mattermost.LogError("Couldn't save question", "userID", userID, "Error", err.Error())
+		return sendFailure(context, "Your question couldn't be saved, please try again later or contact your System Administrator.")
+	}
+	memberIDs, err := techbuzz.GetTagMemberIDs(tag)
+	if err != nil {
+		config.Mattermost.LogError("Couldn't get members to send question to", "userID", userID, "tag", tag, "Error", err.Error())
+		return sendFailure(context, "Your question couldn't be sent, please try again later or contact your System Administrator.")
+	}
+	if config.GetConfig().GetMaxDMsPerMinute() == 0 {
+		techbuzz.PostQuestion(memberIDs, question, userID, questionID)
+	} else {
+		// throttled DMs are sent in the background to not hold up the command response
+		go techbuzz.PostQuestion(memberIDs, question, userID, questionID)
+	}
 	return &model.CommandResponse{
 		Type: model.COMMAND_RESPONSE_TYPE_EPHEMERAL,
 		Text: "Creativity flows when curiosity is stoked :smile:.",
@@ -1,8 +1,6 @@
 package command
 
 import (
-	"strings"
-
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/techbot/server/techbuzz"
 )
@@ -16,28 +14,12 @@ func commandSubscribeTopics() *Config {
 			AutoCompleteHint: "<tags...>",
 		},
 		HelpText: "",
-		Validate: validateConfig,
+		Validate: validateTags,
 		Execute:  saveConfig,
 	}
 }
 
-func validateConfig(args []string, context Context) (*model.CommandResponse, *model.AppError) {
-	tagsNotFound := []string{}
-	tags := []string{}
-	for _, arg := range args {
-		if techbuzz.TechTag[strings.ToLower(arg)] {
-			tags = append(tags, strings.ToLower(arg))
-		} else {
-			tagsNotFound = append(tagsNotFound, strings.ToLower(arg))
-		}
-	}
-	context.Props["tags"] = tags
-	context.Props["tagsNotFound"] = tagsNotFound
-
-	return nil, nil
-}
-
-func saveConfig(args []string, context Context) (*model.CommandResponse, *model.AppError) {
+func saveConfig(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
 	userID := context.CommandArgs.UserId
 	if len(args) == 0 {
 		techbuzz.SaveUserConfig(userID, techbuzz.TechList)
@@ -47,8 +29,8 @@ func saveConfig(args []string, context Context) (*model.CommandResponse, *model.
 		}, nil
 	}
 	var tt,te string
-	tags := context.Props["tags"].([]string)
-	tagsNotFound := context.Props["tagsNotFound"].([]string)
+	tags := context.Tags
+	tagsNotFound := context.TagsNotFound
 	techbuzz.SaveUserConfig(userID, tags)
 	for _, val := range tags {
 		te = te + " " + val
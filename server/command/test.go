@@ -13,17 +13,15 @@ func commandInsertData() *Config {
 			AutoComplete:     true,
 		},
 		HelpText: "",
-		Validate: validatedata,
+		Validate: validateAll(validateSystemAdmin, validateArgsCount(2)),
 		Execute:  insertData,
 	}
 }
 
-func validatedata(args []string, context Context) (*model.CommandResponse, *model.AppError) {
-	return nil, nil
-}
-
-func insertData(args []string, context Context) (*model.CommandResponse, *model.AppError) {
-	techbuzz.InsertData(args[0], args[1])
+func insertData(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
+	if err := techbuzz.InsertData(args[0], args[1]); err != nil {
+		return sendFailure(context, err.Error())
+	}
 	return &model.CommandResponse{
 		Type: model.COMMAND_RESPONSE_TYPE_EPHEMERAL,
 		Text: "-",
@@ -1,8 +1,6 @@
 package command
 
 import (
-	"strings"
-
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/techbot/server/techbuzz"
 )
@@ -16,32 +14,16 @@ func commandUnsubscribeTopics() *Config {
 			AutoCompleteHint: "<tags...>",
 		},
 		HelpText: "",
-		Validate: validate,
+		Validate: validateAll(validateSubscribed, validateTags),
 		Execute:  unsubscribe,
 	}
 }
 
-func validate(args []string, context Context) (*model.CommandResponse, *model.AppError) {
-	tagsNotFound := []string{}
-	tags := []string{}
-	for _, arg := range args {
-		if techbuzz.TechTag[strings.ToLower(arg)] {
-			tags = append(tags, strings.ToLower(arg))
-		} else {
-			tagsNotFound = append(tagsNotFound, strings.ToLower(arg))
-		}
-	}
-	context.Props["tags"] = tags
-	context.Props["tagsNotFound"] = tagsNotFound
-
-	return nil, nil
-}
-
-func unsubscribe(args []string, context Context) (*model.CommandResponse, *model.AppError) {
+func unsubscribe(args []string, context *Context) (*model.CommandResponse, *model.AppError) {
 	userID := context.CommandArgs.UserId
 	if len(args) == 0 {
-		userConfig := techbuzz.GetUserConfig(userID)
-		userConfig.Enabled =false
+		userConfig := context.UserConfig
+		userConfig.Enabled = false
 
 		techbuzz.SaveConfig(userID, userConfig)
 		techbuzz.Unsubscribe(userID, techbuzz.TechList)
@@ -52,8 +34,8 @@ func unsubscribe(args []string, context Context) (*model.CommandResponse, *model
 	}
 
 	var tt,te string
-	tags := context.Props["tags"].([]string)
-	tagsNotFound := context.Props["tagsNotFound"].([]string)
+	tags := context.Tags
+	tagsNotFound := context.TagsNotFound
 	techbuzz.Unsubscribe(userID, tags)
 	for _, val := range tags {
 		te = te + " " + val
@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/pkg/errors"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,7 +11,8 @@ import (
 )
 
 const (
-	CommandPrefix       = "techbot"
+	DefaultCommandTrigger = "techbot"
+
 	TechMembers         = "tech_members1"
 	UserConfig          = "user1_config"
 	TechData            = "tech_data1"
@@ -19,9 +21,12 @@ const (
 	BotUsername         = "techbot"
 	BotDisplayName      = "TechBot"
 
-	URLPluginBase  = "/plugins/" + "techbot"
-	URLStaticBase  = URLPluginBase + "/static"
-	RunnerInterval = 60 * time.Second
+	URLPluginBase = "/plugins/" + "techbot"
+	URLStaticBase = URLPluginBase + "/static"
+
+	DefaultRunnerInterval = 60 * time.Second
+	MinRunnerInterval     = 10 * time.Second
+	MaxRunnerInterval     = 24 * time.Hour
 
 	HeaderMattermostUserID = "Mattermost-User-Id"
 )
@@ -37,6 +42,12 @@ type Configuration struct {
 	Apikey    string `json:"Apikey"`
 	TechBuzzChannel string `json:"TechBuzzChannel"`
 	AskJtgChannel string `json:"AskJtgChannel"`
+	RunnerInterval  string `json:"RunnerInterval"`
+	CommandTrigger  string `json:"CommandTrigger"`
+	MaxDMsPerMinute string `json:"MaxDMsPerMinute"`
+
+	runnerInterval  time.Duration
+	maxDMsPerMinute int
 }
 
 func GetConfig() *Configuration {
@@ -50,6 +61,30 @@ func SetConfig(c *Configuration) {
 func (c *Configuration) ProcessConfiguration() error {
 	c.Apikey = strings.TrimSpace(c.Apikey)
 	c.TechBuzzChannel = strings.TrimSpace(c.TechBuzzChannel)
+	c.RunnerInterval = strings.TrimSpace(c.RunnerInterval)
+	c.CommandTrigger = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(c.CommandTrigger), "/"))
+	if c.CommandTrigger == "" {
+		c.CommandTrigger = DefaultCommandTrigger
+	}
+
+	c.runnerInterval = DefaultRunnerInterval
+	if c.RunnerInterval != "" {
+		runnerInterval, err := time.ParseDuration(c.RunnerInterval)
+		if err != nil {
+			return errors.Wrap(err, "invalid Runner Interval")
+		}
+		c.runnerInterval = runnerInterval
+	}
+
+	c.MaxDMsPerMinute = strings.TrimSpace(c.MaxDMsPerMinute)
+	c.maxDMsPerMinute = 0
+	if c.MaxDMsPerMinute != "" {
+		maxDMsPerMinute, err := strconv.Atoi(c.MaxDMsPerMinute)
+		if err != nil {
+			return errors.Wrap(err, "invalid Max DMs Per Minute")
+		}
+		c.maxDMsPerMinute = maxDMsPerMinute
+	}
 
 	return nil
 }
@@ -63,5 +98,27 @@ func (c *Configuration) IsValid() error {
 		return errors.Wrap(errors.New(appErr.Error()), "invalid TechBuzz Channel ID")
 	}
 
+	if strings.ContainsAny(c.CommandTrigger, " /") {
+		return errors.New("Slash Command Trigger cannot contain spaces or slashes")
+	}
+
+	if c.runnerInterval < MinRunnerInterval || c.runnerInterval > MaxRunnerInterval {
+		return errors.Errorf("Runner Interval must be between %s and %s", MinRunnerInterval, MaxRunnerInterval)
+	}
+
+	if c.maxDMsPerMinute < 0 {
+		return errors.New("Max DMs Per Minute cannot be negative")
+	}
+
 	return nil
 }
+
+// GetRunnerInterval returns how often the runner checks for tech posts to send.
+func (c *Configuration) GetRunnerInterval() time.Duration {
+	return c.runnerInterval
+}
+
+// GetMaxDMsPerMinute returns the maximum number of DMs the bot sends per minute, 0 meaning no limit.
+func (c *Configuration) GetMaxDMsPerMinute() int {
+	return c.maxDMsPerMinute
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/techbot/server/config"
+	"github.com/techbot/server/platform"
 	"github.com/techbot/server/techbuzz"
 )
 
@@ -40,8 +41,16 @@ func sentAnswerToUser(w http.ResponseWriter, r *http.Request) {
 	questionText := feedback["state"].(string)
 	responseBy := feedback["user_id"].(string)
 	answer := feedback["submission"].(map[string]interface{})["Answer"].(string)
-	user,_ := config.Mattermost.GetUser(responseBy)
-	channel, _ := config.Mattermost.GetDirectChannel(config.GetConfig().BotUserID, userID)
+	user, appErr := platform.GetUser(responseBy)
+	if appErr != nil {
+		http.Error(w, appErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	channel, appErr := platform.GetDirectChannel(config.GetConfig().BotUserID, userID)
+	if appErr != nil {
+		http.Error(w, appErr.Error(), http.StatusInternalServerError)
+		return
+	}
 	post := &model.Post{
 		ChannelId: channel.Id,
 		UserId:    config.GetConfig().BotUserID,
@@ -52,7 +61,7 @@ func sentAnswerToUser(w http.ResponseWriter, r *http.Request) {
 			Text: fmt.Sprintf("**Q.** %s \n\n**A.** %s", questionText, answer),
 		},
 	})
-	config.Mattermost.CreatePost(post)
+	platform.CreatePost(post)
 
 	post1 := &model.Post{
 		ChannelId: config.GetConfig().AskJtgChannel,
@@ -64,8 +73,12 @@ func sentAnswerToUser(w http.ResponseWriter, r *http.Request) {
 			Text: fmt.Sprintf("**Q.** %s \n\n**A.** %s", questionText, answer),
 		},
 	})
-	config.Mattermost.CreatePost(post1)
-	channelDM, _:= config.Mattermost.GetDirectChannel(config.GetConfig().BotUserID, responseBy)
+	platform.CreatePost(post1)
+	channelDM, appErr := platform.GetDirectChannel(config.GetConfig().BotUserID, responseBy)
+	if appErr != nil {
+		http.Error(w, appErr.Error(), http.StatusInternalServerError)
+		return
+	}
 	config.Mattermost.SendEphemeralPost(responseBy, &model.Post{
 		ChannelId: channelDM.Id,
 		Message:   " Keeping knowledge erodes power. Sharing is the fuel to your growth engine :wink:.",
@@ -75,12 +88,17 @@ func submitAnswer(w http.ResponseWriter, r *http.Request) {
 	questionID := r.URL.Query().Get("id")
 	userID := r.URL.Query().Get("user_id")
 	id, _ := strconv.Atoi(questionID)
-	question := techbuzz.GetQuestionByID(id)
+	question, err := techbuzz.GetQuestionByID(id)
+	if err != nil {
+		config.Mattermost.LogError("Unable to get question.", "questionID", questionID, "Error", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	decoder := json.NewDecoder(r.Body)
 	params := &model.PostActionIntegrationRequest{}
 
-	if err := decoder.Decode(&params); err != nil {
+	if err = decoder.Decode(&params); err != nil {
 		config.Mattermost.LogError("Error decoding PostActionIntegrationRequest params: ", err.Error())
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
@@ -103,7 +121,7 @@ func submitAnswer(w http.ResponseWriter, r *http.Request) {
 
 	response := &model.PostActionIntegrationResponse{}
 	w.Header().Set("Content-Type", "application/json")
-	if _, err := w.Write(response.ToJson()); err != nil {
+	if _, err = w.Write(response.ToJson()); err != nil {
 		config.Mattermost.LogWarn("failed to write PostActionIntegrationResponse", "Error", err.Error())
 	}
 }
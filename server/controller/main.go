@@ -3,6 +3,7 @@ package controller
 import (
 	"net/http"
 
+	"github.com/mattermost/mattermost-server/model"
 	"github.com/techbot/server/config"
 )
 
@@ -30,3 +31,18 @@ func Authenticated(w http.ResponseWriter, r *http.Request) bool {
 
 	return true
 }
+
+// AuthenticatedSystemAdmin verifies if provided request is performed by a logged-in Mattermost system admin.
+func AuthenticatedSystemAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if !Authenticated(w, r) {
+		return false
+	}
+
+	userID := r.Header.Get(config.HeaderMattermostUserID)
+	if !config.Mattermost.HasPermissionTo(userID, model.PERMISSION_MANAGE_SYSTEM) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
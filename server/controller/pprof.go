@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"strings"
+)
+
+const (
+	pprofIndexPath  = "/debug/pprof"
+	pprofPathPrefix = pprofIndexPath + "/"
+)
+
+var pprofHandlers = map[string]http.HandlerFunc{
+	pprofPathPrefix + "cmdline": pprof.Cmdline,
+	pprofPathPrefix + "profile": pprof.Profile,
+	pprofPathPrefix + "symbol":  pprof.Symbol,
+	pprofPathPrefix + "trace":   pprof.Trace,
+}
+
+// IsPprofPath reports whether path points to one of the profiling endpoints.
+func IsPprofPath(path string) bool {
+	return path == pprofIndexPath || strings.HasPrefix(path, pprofPathPrefix)
+}
+
+// ServePprof serves the net/http/pprof profiles, restricted to system admins.
+// Named profiles such as heap and goroutine are served by the pprof index handler.
+func ServePprof(w http.ResponseWriter, r *http.Request) {
+	if !AuthenticatedSystemAdmin(w, r) {
+		return
+	}
+
+	// the index links to the profiles relative to itself, so it needs the trailing slash.
+	// The Location is relative as the path seen here lacks the plugin's URL prefix,
+	// which http.Redirect would otherwise drop when making it absolute.
+	if r.URL.Path == pprofIndexPath {
+		w.Header().Set("Location", "pprof/")
+		w.WriteHeader(http.StatusMovedPermanently)
+		return
+	}
+
+	if handler, ok := pprofHandlers[r.URL.Path]; ok {
+		handler(w, r)
+		return
+	}
+
+	pprof.Index(w, r)
+}
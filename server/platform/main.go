@@ -1 +1,86 @@
 package platform
+
+import (
+	"fmt"
+
+	"github.com/mattermost/mattermost-server/model"
+
+	"github.com/techbot/server/config"
+	"github.com/techbot/server/util"
+)
+
+// KVValueSizeWarningThreshold is the value size beyond which KVSet warns that the value is approaching the KV store limit.
+// MySQL stores plugin KV values as a MEDIUMBLOB, capped at 16MB.
+const KVValueSizeWarningThreshold = 12 * 1024 * 1024
+
+// KVGet fetches the value stored against key, retrying transient failures.
+func KVGet(key string) ([]byte, *model.AppError) {
+	var data []byte
+	appErr := retry("KVGet", func() *model.AppError {
+		var err *model.AppError
+		data, err = config.Mattermost.KVGet(key)
+		return err
+	})
+	return data, appErr
+}
+
+// KVSet stores value against key, retrying transient failures.
+// It warns when value is close to the KV store size limit, as writes beyond it fail.
+func KVSet(key string, value []byte) *model.AppError {
+	if len(value) >= KVValueSizeWarningThreshold {
+		config.Mattermost.LogWarn(
+			"KV value is approaching the KV store size limit",
+			"key", key,
+			"size", fmt.Sprintf("%d bytes", len(value)),
+		)
+	}
+
+	return retry("KVSet", func() *model.AppError {
+		return config.Mattermost.KVSet(key, value)
+	})
+}
+
+// CreatePost creates post. Unlike the other calls here it is not retried:
+// the post may already have been stored when an error is returned, so retrying could post it twice.
+func CreatePost(post *model.Post) (*model.Post, *model.AppError) {
+	createdPost, appErr := config.Mattermost.CreatePost(post)
+	if appErr != nil {
+		config.Mattermost.LogError("CreatePost failed", "channelID", post.ChannelId, "Error", appErr.Error())
+	}
+	return createdPost, appErr
+}
+
+// GetDirectChannel gets or creates the DM channel between userID1 and userID2, retrying transient failures.
+func GetDirectChannel(userID1, userID2 string) (*model.Channel, *model.AppError) {
+	var channel *model.Channel
+	appErr := retry("GetDirectChannel", func() *model.AppError {
+		var err *model.AppError
+		channel, err = config.Mattermost.GetDirectChannel(userID1, userID2)
+		return err
+	})
+	return channel, appErr
+}
+
+// GetUser fetches the user with userID, retrying transient failures.
+func GetUser(userID string) (*model.User, *model.AppError) {
+	var user *model.User
+	appErr := retry("GetUser", func() *model.AppError {
+		var err *model.AppError
+		user, err = config.Mattermost.GetUser(userID)
+		return err
+	})
+	return user, appErr
+}
+
+func retry(operation string, fn func() *model.AppError) *model.AppError {
+	attempts, appErr := util.Retry(fn)
+	if appErr != nil {
+		config.Mattermost.LogError(
+			operation+" failed",
+			"attempts", attempts,
+			"permanent", util.IsPermanent(appErr),
+			"Error", appErr.Error(),
+		)
+	}
+	return appErr
+}
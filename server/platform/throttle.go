@@ -0,0 +1,42 @@
+package platform
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+
+	"github.com/techbot/server/config"
+)
+
+// dmThrottle hands out evenly spaced send slots so that no more than the configured number of DMs go out per minute.
+var dmThrottle struct {
+	sync.Mutex
+	next time.Time
+}
+
+// CreateDirectPost creates a DM post, first waiting for a send slot if DMs are being throttled.
+func CreateDirectPost(post *model.Post) (*model.Post, *model.AppError) {
+	waitForDMSlot()
+	return CreatePost(post)
+}
+
+func waitForDMSlot() {
+	maxDMsPerMinute := config.GetConfig().GetMaxDMsPerMinute()
+	if maxDMsPerMinute == 0 {
+		return
+	}
+
+	interval := time.Minute / time.Duration(maxDMsPerMinute)
+
+	dmThrottle.Lock()
+	now := time.Now()
+	if dmThrottle.next.Before(now) {
+		dmThrottle.next = now
+	}
+	wait := dmThrottle.next.Sub(now)
+	dmThrottle.next = dmThrottle.next.Add(interval)
+	dmThrottle.Unlock()
+
+	time.Sleep(wait)
+}
@@ -20,8 +20,9 @@ import (
 
 type Plugin struct {
 	plugin.MattermostPlugin
-	running bool
-	handler http.Handler
+	running        bool
+	handler        http.Handler
+	commandTrigger string
 }
 
 func (p *Plugin) OnActivate() error {
@@ -46,6 +47,11 @@ func (p *Plugin) OnActivate() error {
 	return nil
 }
 
+func (p *Plugin) OnDeactivate() error {
+	techbuzz.LogUndeliveredQuestions()
+	return nil
+}
+
 func (p *Plugin) setupStaticFileServer() error {
 	return nil
 }
@@ -75,7 +81,21 @@ func (p *Plugin) OnConfigurationChange() error {
 			return err
 		}
 
+		// commands are registered for the first time in OnActivate,
+		// here they only need to be re-registered if the trigger was changed afterwards.
+		if p.commandTrigger == "" || p.commandTrigger == configuration.CommandTrigger {
+			config.SetConfig(&configuration)
+			return nil
+		}
+
+		// the new trigger is read from the config, so the previous one is restored if it can't be registered
+		previousConfiguration := config.GetConfig()
 		config.SetConfig(&configuration)
+		if err := p.RegisterCommands(); err != nil {
+			config.SetConfig(previousConfiguration)
+			config.Mattermost.LogError("Error in re-registering commands: " + err.Error())
+			return err
+		}
 	}
 	return nil
 }
@@ -93,17 +113,35 @@ func (p *Plugin) setUpBot() (string, error) {
 	return botID, nil
 }
 
+// RegisterCommands registers the master command under the configured trigger.
+// A previously registered trigger is unregistered only once the new one is in place, so the commands are never left unregistered.
 func (p *Plugin) RegisterCommands() error {
-	if err := config.Mattermost.RegisterCommand(command.Master().Command); err != nil {
+	masterCommand := command.Master().Command
+
+	if err := config.Mattermost.RegisterCommand(masterCommand); err != nil {
 		return err
 	}
 
+	previousTrigger := p.commandTrigger
+	p.commandTrigger = masterCommand.Trigger
+
+	if previousTrigger != "" && previousTrigger != masterCommand.Trigger {
+		// the new trigger is already live and the stale one is rejected by ExecuteCommand, so this isn't fatal
+		if err := config.Mattermost.UnregisterCommand("", previousTrigger); err != nil {
+			config.Mattermost.LogWarn("Couldn't unregister the previous command trigger", "trigger", previousTrigger, "Error", err.Error())
+		}
+	}
+
 	return nil
 }
 
 func (p *Plugin) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
-	split, argErr := util.SplitArgs(args.Command)
+	start := time.Now()
+
+	// only the trigger and the sub-command are split here, the sub-command's own arguments are split by the master command
+	split, rawArgs, argErr := util.SplitArgsN(args.Command, 2)
 	if argErr != nil {
+		p.logCommandExecution(args, nil, "invalid", time.Since(start), nil)
 		return &model.CommandResponse{
 			Type: model.COMMAND_RESPONSE_TYPE_EPHEMERAL,
 			Text: argErr.Error(),
@@ -118,26 +156,73 @@ func (p *Plugin) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*mo
 	}
 
 	if function != "/"+command.Master().Command.Trigger {
-		return nil, &model.AppError{Message: "Unknown command: [" + function + "] encountered"}
+		appErr := &model.AppError{Message: "Unknown command: [" + function + "] encountered"}
+		p.logCommandExecution(args, params, "error", time.Since(start), appErr)
+		return nil, appErr
+	}
+
+	context := p.prepareContext(args, rawArgs)
+
+	outcome := "invalid"
+	response, appErr := command.Master().Validate(params, context)
+	if response == nil {
+		outcome = "success"
+		response, appErr = command.Master().Execute(params, context)
+	}
+
+	if appErr != nil {
+		outcome = "error"
+	} else if context.Failed {
+		outcome = "failed"
 	}
 
-	context := p.prepareContext(args)
-	if response, err := command.Master().Validate(params, context); response != nil {
-		return response, err
+	p.logCommandExecution(args, params, outcome, time.Since(start), appErr)
+	return response, appErr
+}
+
+// logCommandExecution logs the sub-command, caller, outcome and duration of a command execution.
+// Command arguments are deliberately left out as they may contain user content.
+func (p *Plugin) logCommandExecution(args *model.CommandArgs, params []string, outcome string, duration time.Duration, appErr *model.AppError) {
+	subCommand := ""
+	if len(params) > 0 {
+		subCommand = params[0]
+	}
+
+	keyValuePairs := []interface{}{
+		"command", subCommand,
+		"userID", args.UserId,
+		"channelID", args.ChannelId,
+		"outcome", outcome,
+		"duration", duration.String(),
+	}
+
+	if appErr != nil {
+		config.Mattermost.LogError("Command execution failed", append(keyValuePairs, "Error", appErr.Error())...)
+		return
+	}
+
+	if outcome == "failed" {
+		config.Mattermost.LogError("Command execution failed", keyValuePairs...)
+		return
 	}
 
-	// todo add error logs here
-	return command.Master().Execute(params, context)
+	config.Mattermost.LogInfo("Command executed", keyValuePairs...)
 }
 
-func (p *Plugin) prepareContext(args *model.CommandArgs) command.Context {
-	return command.Context{
+func (p *Plugin) prepareContext(args *model.CommandArgs, rawArgs string) *command.Context {
+	return &command.Context{
 		CommandArgs: args,
-		Props:       make(map[string]interface{}),
+		RawArgs:     rawArgs,
 	}
 }
 
 func (p *Plugin) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
+	// profiling is served even when the plugin is misconfigured, as that's when it's needed the most
+	if controller.IsPprofPath(r.URL.Path) {
+		controller.ServePprof(w, r)
+		return
+	}
+
 	conf := config.GetConfig()
 
 	if err := conf.IsValid(); err != nil {
@@ -150,6 +235,10 @@ func (p *Plugin) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Req
 	endpoint := controller.Endpoints[path]
 
 	if endpoint == nil {
+		if p.handler == nil {
+			http.NotFound(w, r)
+			return
+		}
 		p.handler.ServeHTTP(w, r)
 	} else {
 		endpoint.Execute(w, r)
@@ -165,7 +254,7 @@ func (p *Plugin) Run() {
 
 func (p *Plugin) runner() {
 	go func() {
-		<-time.NewTimer(config.RunnerInterval).C
+		<-time.NewTimer(config.GetConfig().GetRunnerInterval()).C
 		techbuzz.SendPost()
 		if !p.running {
 			return
@@ -194,7 +283,7 @@ func (p *Plugin) MessageHasBeenPosted(c *plugin.Context, post *model.Post) {
 			for val, _ := range response.Result.(map[string]interface{}) {
 				if techbuzz.TechTag[strings.ToLower(val)] {
 					//frequency := int (fre.(float64))
-					techbuzz.InsertData(strings.ToLower(val),post.Message)
+					p.insertTechData(strings.ToLower(val), post)
 					fmt.Println("From Api", val)
 					flag =true
 				}
@@ -206,18 +295,25 @@ func (p *Plugin) MessageHasBeenPosted(c *plugin.Context, post *model.Post) {
 		if flag == false {
 			for val, _ := range techbuzz.TechTag {
 				if strings.Contains(strings.ToLower(URL),val) {
-					techbuzz.InsertData(strings.ToLower(val),post.Message)
+					p.insertTechData(strings.ToLower(val), post)
 					fmt.Println("From tag search", val)
 					break
 				}
 			}
 		} else {
 			fmt.Println("Insertin in other tag:")
-			techbuzz.InsertData("other",post.Message)
+			p.insertTechData("other", post)
 		}
 	}
 }
 
+// insertTechData stores the post's message under tag, logging failures as the link would otherwise be lost silently.
+func (p *Plugin) insertTechData(tag string, post *model.Post) {
+	if err := techbuzz.InsertData(tag, post.Message); err != nil {
+		config.Mattermost.LogError("Couldn't store link", "tag", tag, "postID", post.Id, "Error", err.Error())
+	}
+}
+
 func main() {
 	plugin.ClientMain(&Plugin{})
 }
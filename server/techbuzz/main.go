@@ -2,12 +2,14 @@ package techbuzz
 
 import (
 	"encoding/json"
+	"github.com/pkg/errors"
 	"github.com/thoas/go-funk"
 	"strings"
 
 	"github.com/standup-raven/standup-raven/server/logger"
 	"github.com/standup-raven/standup-raven/server/util"
 	"github.com/techbot/server/config"
+	"github.com/techbot/server/platform"
 )
 
 var TechTag = map[string]bool{
@@ -98,15 +100,21 @@ func SaveUserConfig(userID string, tags []string) error {
 	return nil
 }
 
-func GetTechMembers() []string {
-	data, _ := config.Mattermost.KVGet(util.GetKeyHash(config.TechMembers))
+// GetTechMembers returns the IDs of all users who have ever subscribed.
+// Callers that modify and store the list must not proceed on error, as that would overwrite the stored list.
+func GetTechMembers() ([]string, error) {
 	var users []string
-	json.Unmarshal(data, &users)
-	return users
+	if err := kvGetJSON(util.GetKeyHash(config.TechMembers), &users); err != nil {
+		return nil, errors.Wrap(err, "couldn't get tech members")
+	}
+	return users, nil
 }
 
 func AddTechMembers(userID string) error {
-	users := GetTechMembers()
+	users, err := GetTechMembers()
+	if err != nil {
+		return err
+	}
 	users = append(users, userID)
 
 	serilizedData, err := json.Marshal(users)
@@ -115,14 +123,14 @@ func AddTechMembers(userID string) error {
 		return err
 	}
 
-	if err := config.Mattermost.KVSet(util.GetKeyHash(config.TechMembers), serilizedData); err != nil {
-		return err
+	if appErr := platform.KVSet(util.GetKeyHash(config.TechMembers), serilizedData); appErr != nil {
+		return appErr
 	}
 	return nil
 }
 
 func GetUserConfig(userID string) *UserConfig {
-	data, _ := config.Mattermost.KVGet(util.GetKeyHash(config.UserConfig + "_" + userID))
+	data, _ := platform.KVGet(util.GetKeyHash(config.UserConfig + "_" + userID))
 	if len(data) == 0 {
 		return nil
 	}
@@ -141,21 +149,26 @@ func SaveConfig(userID string, userConfig *UserConfig) error {
 		return err
 	}
 
-	if err := config.Mattermost.KVSet(util.GetKeyHash(config.UserConfig+"_"+userID), serilizedData); err != nil {
+	if err := platform.KVSet(util.GetKeyHash(config.UserConfig+"_"+userID), serilizedData); err != nil {
 		return err
 	}
 	return nil
 }
 
-func GetData(tag string) []string {
-	data, _ := config.Mattermost.KVGet(util.GetKeyHash(config.TechData + "_" + tag))
+// GetData returns the tech posts queued under tag.
+func GetData(tag string) ([]string, error) {
 	var techData []string
-	json.Unmarshal(data, &techData)
-	return techData
+	if err := kvGetJSON(util.GetKeyHash(config.TechData+"_"+tag), &techData); err != nil {
+		return nil, errors.Wrapf(err, "couldn't get tech data for tag %s", tag)
+	}
+	return techData, nil
 }
 
 func InsertData(tag string, text string) error {
-	techData := GetData(strings.ToLower(tag))
+	techData, err := GetData(strings.ToLower(tag))
+	if err != nil {
+		return err
+	}
 	techData = append(techData, text)
 	serilizedData, err := json.Marshal(techData)
 	if err != nil {
@@ -163,28 +176,56 @@ func InsertData(tag string, text string) error {
 		return err
 	}
 
-	if err := config.Mattermost.KVSet(util.GetKeyHash(config.TechData+"_"+strings.ToLower(tag)), serilizedData); err != nil {
-		return err
+	if appErr := platform.KVSet(util.GetKeyHash(config.TechData+"_"+strings.ToLower(tag)), serilizedData); appErr != nil {
+		return errors.Wrapf(appErr, "couldn't store tech data for tag %s (%d bytes)", tag, len(serilizedData))
 	}
 	return nil
 }
-func GetQuestionByID(questionID int) string {
-	techQuestions := Getquestions()
-	return techQuestions[questionID-1]
+func GetQuestionByID(questionID int) (string, error) {
+	techQuestions, err := Getquestions()
+	if err != nil {
+		return "", err
+	}
+	if questionID < 1 || questionID > len(techQuestions) {
+		return "", errors.Errorf("question %d not found", questionID)
+	}
+	return techQuestions[questionID-1], nil
 }
 
-func Getquestions() []string {
-	data, _ := config.Mattermost.KVGet(util.GetKeyHash(config.TechQuestions))
+func Getquestions() ([]string, error) {
 	var techQuestions []string
-	json.Unmarshal(data, &techQuestions)
-	return techQuestions
+	if err := kvGetJSON(util.GetKeyHash(config.TechQuestions), &techQuestions); err != nil {
+		return nil, errors.Wrap(err, "couldn't get tech questions")
+	}
+	return techQuestions, nil
 }
 
-func AddQuestion(text string) int {
-	techQuestions := Getquestions()
+func AddQuestion(text string) (int, error) {
+	techQuestions, err := Getquestions()
+	if err != nil {
+		return 0, err
+	}
 	techQuestions = append(techQuestions, text)
-	serilizedData, _ := json.Marshal(techQuestions)
+	serilizedData, err := json.Marshal(techQuestions)
+	if err != nil {
+		logger.Error("Couldn't marshal tech questions", err, nil)
+		return 0, err
+	}
+
+	if appErr := platform.KVSet(util.GetKeyHash(config.TechQuestions), serilizedData); appErr != nil {
+		return 0, errors.Wrapf(appErr, "couldn't store tech questions (%d bytes)", len(serilizedData))
+	}
+	return len(techQuestions), nil
+}
 
-	config.Mattermost.KVSet(util.GetKeyHash(config.TechQuestions), serilizedData)
-	return len(techQuestions)
+// kvGetJSON unmarshals the JSON value stored against key into v, leaving v untouched if nothing is stored.
+func kvGetJSON(key string, v interface{}) error {
+	data, appErr := platform.KVGet(key)
+	if appErr != nil {
+		return appErr
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
 }
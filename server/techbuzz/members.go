@@ -4,20 +4,22 @@ import (
 	"strings"
 )
 
-func GetTagMemberIDs(tag string) []string {
+func GetTagMemberIDs(tag string) ([]string, error) {
 	memberIDs := []string{}
-	usersIDs := GetTechMembers()
+	usersIDs, err := GetTechMembers()
+	if err != nil {
+		return nil, err
+	}
 	for _, userID := range usersIDs {
 		userConfig := GetUserConfig(userID)
-		if userConfig.Enabled == false {
+		if userConfig == nil || !userConfig.Enabled {
 			continue
-		} else {
-			for key, value := range userConfig.Tags {
-				if key == strings.ToLower(tag) && value.Enabled == true {
-					memberIDs = append(memberIDs, userID)
-				}
+		}
+		for key, value := range userConfig.Tags {
+			if key == strings.ToLower(tag) && value.Enabled == true {
+				memberIDs = append(memberIDs, userID)
 			}
 		}
 	}
-	return memberIDs
+	return memberIDs, nil
 }
@@ -2,80 +2,200 @@ package techbuzz
 
 import (
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/mattermost/mattermost-server/model"
 	"github.com/techbot/server/config"
+	"github.com/techbot/server/platform"
 )
 
+// progressLogInterval is the number of tech posts sent between progress log lines.
+const progressLogInterval = 100
+
 func SendPost() {
-	usersIDs := GetTechMembers()
-	fmt.Println("length=", len(usersIDs))
+	start := time.Now()
+	sent := 0
+	usersIDs, err := GetTechMembers()
+	if err != nil {
+		config.Mattermost.LogError("Couldn't send tech posts", "Error", err.Error())
+		return
+	}
 	for _, userID := range usersIDs {
 		userConfig := GetUserConfig(userID)
-		fmt.Println("userConfig=", userConfig)
-		if userConfig.Enabled == false {
+		if userConfig == nil || userConfig.Enabled == false {
 			continue
-		} else {
-			var newTags = make(map[string]Tag)
-			for key, value := range userConfig.Tags {
-				if value.Enabled == true {
-					if sendTechPost(key,userID,value.SequenceNumber) {
-						value.SequenceNumber++
-					}
-				}
-				newTags[key]=value
+		}
+
+		for key, value := range userConfig.Tags {
+			if value.Enabled == false || !sendTechPost(key, userID, value.SequenceNumber) {
+				continue
+			}
+
+			sent++
+			if err = advanceSequenceNumber(userID, key, value.SequenceNumber); err != nil {
+				config.Mattermost.LogError("Couldn't save tech post sequence number", "userID", userID, "tag", key, "Error", err.Error())
+			}
+
+			if sent%progressLogInterval == 0 {
+				config.Mattermost.LogInfo("Sending tech posts", "sent", sent, "elapsed", time.Since(start).String())
 			}
-			userConfig.Tags= newTags
-			SaveConfig(userID, userConfig)
 		}
 	}
+
+	if sent > 0 {
+		config.Mattermost.LogInfo("Sent tech posts", "sent", sent, "users", len(usersIDs), "duration", time.Since(start).String())
+	}
+}
+
+// advanceSequenceNumber records that the post at sentSequenceNumber was sent for tag.
+// The config is read afresh as sending may have waited on the DM throttle,
+// during which the user may have changed their subscriptions.
+func advanceSequenceNumber(userID, tag string, sentSequenceNumber int) error {
+	userConfig := GetUserConfig(userID)
+	if userConfig == nil {
+		return nil
+	}
+
+	value, ok := userConfig.Tags[tag]
+	if !ok {
+		return nil
+	}
+
+	value.SequenceNumber = sentSequenceNumber + 1
+	userConfig.Tags[tag] = value
+	return SaveConfig(userID, userConfig)
 }
 
 func sendTechPost(tag ,userID string, sequenceNumber int) bool {
-	techData := GetData(tag)
+	techData, err := GetData(tag)
+	if err != nil {
+		config.Mattermost.LogError("Couldn't send tech post", "userID", userID, "Error", err.Error())
+		return false
+	}
 	if len(techData) <= sequenceNumber {
 		return false
 	}
-	channel, _ := config.Mattermost.GetDirectChannel(config.GetConfig().BotUserID, userID)
+	channel, appErr := platform.GetDirectChannel(config.GetConfig().BotUserID, userID)
+	if appErr != nil {
+		return false
+	}
 	post := &model.Post{
 		ChannelId: channel.Id,
 		UserId:    config.GetConfig().BotUserID,
 		Message:   techData[sequenceNumber],
 	}
-	_, err := config.Mattermost.CreatePost(post)
-	if err != nil {
+	if _, appErr = platform.CreateDirectPost(post); appErr != nil {
 		return false
 	}
 	return true
 }
 
+type pendingQuestion struct {
+	questionID int
+	members    []string
+}
+
+// pendingQuestionDMs holds, per PostQuestion call, the members a question is still to be sent to.
+// Calls are keyed by a token of their own as concurrent calls may share a question ID.
+var pendingQuestionDMs = struct {
+	sync.Mutex
+	nextToken uint64
+	questions map[uint64]pendingQuestion
+}{questions: make(map[uint64]pendingQuestion)}
+
 func PostQuestion(userIDs []string, text string, userID string, questionID int) {
+	recipients := []string{}
 	for _, id := range userIDs {
-		if id == userID {
-			continue
+		if id != userID {
+			recipients = append(recipients, id)
 		}
-		channel, _ := config.Mattermost.GetDirectChannel(config.GetConfig().BotUserID, id)
-		post := &model.Post{
-			ChannelId: channel.Id,
-			UserId:    config.GetConfig().BotUserID,
-			Message:   "Hi one of our friend's need our help",
+	}
+
+	token := addPendingQuestionDMs(questionID, recipients)
+	defer setPendingQuestionDMs(token, nil)
+
+	failed := []string{}
+	for i, id := range recipients {
+		if !sendQuestion(id, text, userID, questionID) {
+			failed = append(failed, id)
 		}
-		actions := []*model.PostAction{}
-
-		actions = append(actions, &model.PostAction{
-			Type: "button",
-			Name: "Submit Answer",
-			Integration: &model.PostActionIntegration{
-				URL: fmt.Sprintf("%s/plugins/%s/%s?id=%d&user_id=%s", *config.Mattermost.GetConfig().ServiceSettings.SiteURL, config.PluginName, "submit-answer", questionID, userID),
-			},
-		})
-
-		post.AddProp("attachments", []*model.SlackAttachment{
-			{
-				Text:    fmt.Sprintf("**Q.** %s \n", text),
-				Actions: actions,
-			},
-		})
-		config.Mattermost.CreatePost(post)
+		setPendingQuestionDMs(token, recipients[i+1:])
+	}
+
+	if len(failed) > 0 {
+		config.Mattermost.LogError("Couldn't send question to members", "questionID", questionID, "userIDs", strings.Join(failed, ","))
+	}
+}
+
+// LogUndeliveredQuestions logs the members each in-progress question hasn't been sent to yet.
+// It is meant to be called when the plugin stops, as those DMs won't be sent.
+func LogUndeliveredQuestions() {
+	pendingQuestionDMs.Lock()
+	defer pendingQuestionDMs.Unlock()
+
+	for _, question := range pendingQuestionDMs.questions {
+		config.Mattermost.LogError("Question was not sent to members before the plugin stopped", "questionID", question.questionID, "userIDs", strings.Join(question.members, ","))
+	}
+}
+
+// addPendingQuestionDMs records the members questionID is to be sent to, returning the token to update them with.
+func addPendingQuestionDMs(questionID int, members []string) uint64 {
+	pendingQuestionDMs.Lock()
+	defer pendingQuestionDMs.Unlock()
+
+	token := pendingQuestionDMs.nextToken
+	pendingQuestionDMs.nextToken++
+
+	if len(members) > 0 {
+		pendingQuestionDMs.questions[token] = pendingQuestion{questionID: questionID, members: members}
+	}
+	return token
+}
+
+func setPendingQuestionDMs(token uint64, members []string) {
+	pendingQuestionDMs.Lock()
+	defer pendingQuestionDMs.Unlock()
+
+	question, ok := pendingQuestionDMs.questions[token]
+	if !ok {
+		return
+	}
+	if len(members) == 0 {
+		delete(pendingQuestionDMs.questions, token)
+		return
+	}
+	question.members = members
+	pendingQuestionDMs.questions[token] = question
+}
+
+func sendQuestion(id string, text string, userID string, questionID int) bool {
+	channel, appErr := platform.GetDirectChannel(config.GetConfig().BotUserID, id)
+	if appErr != nil {
+		return false
 	}
+	post := &model.Post{
+		ChannelId: channel.Id,
+		UserId:    config.GetConfig().BotUserID,
+		Message:   "Hi one of our friend's need our help",
+	}
+	actions := []*model.PostAction{}
+
+	actions = append(actions, &model.PostAction{
+		Type: "button",
+		Name: "Submit Answer",
+		Integration: &model.PostActionIntegration{
+			URL: fmt.Sprintf("%s/plugins/%s/%s?id=%d&user_id=%s", *config.Mattermost.GetConfig().ServiceSettings.SiteURL, config.PluginName, "submit-answer", questionID, userID),
+		},
+	})
+
+	post.AddProp("attachments", []*model.SlackAttachment{
+		{
+			Text:    fmt.Sprintf("**Q.** %s \n", text),
+			Actions: actions,
+		},
+	})
+	_, appErr = platform.CreateDirectPost(post)
+	return appErr == nil
 }
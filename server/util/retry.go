@@ -0,0 +1,51 @@
+package util
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-server/model"
+)
+
+const (
+	RetryMaxAttempts = 4
+	RetryBaseDelay   = 200 * time.Millisecond
+	RetryMaxDelay    = 3 * time.Second
+)
+
+// Retry runs fn until it succeeds, fails permanently or RetryMaxAttempts attempts have been made.
+// Attempts are spaced using exponential backoff with jitter.
+// It returns the number of attempts made along with the last error encountered, if any.
+func Retry(fn func() *model.AppError) (int, *model.AppError) {
+	var appErr *model.AppError
+	for attempt := 1; attempt <= RetryMaxAttempts; attempt++ {
+		if appErr = fn(); appErr == nil || IsPermanent(appErr) {
+			return attempt, appErr
+		}
+
+		if attempt < RetryMaxAttempts {
+			time.Sleep(backoff(attempt))
+		}
+	}
+
+	return RetryMaxAttempts, appErr
+}
+
+// IsPermanent reports whether the server rejected the request itself, in which case retrying won't help.
+func IsPermanent(appErr *model.AppError) bool {
+	return appErr.StatusCode >= http.StatusBadRequest &&
+		appErr.StatusCode < http.StatusInternalServerError &&
+		appErr.StatusCode != http.StatusRequestTimeout &&
+		appErr.StatusCode != http.StatusTooManyRequests
+}
+
+// backoff returns the delay before the next attempt: half of the exponential delay plus a random jitter of up to the other half.
+func backoff(attempt int) time.Duration {
+	delay := RetryBaseDelay << uint(attempt-1)
+	if delay > RetryMaxDelay {
+		delay = RetryMaxDelay
+	}
+
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)))
+}
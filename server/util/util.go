@@ -3,7 +3,6 @@ package util
 import (
 	"crypto/sha256"
 	"encoding/base64"
-	"regexp"
 	"strings"
 
 	"github.com/mattermost/mattermost-server/model"
@@ -11,43 +10,88 @@ import (
 )
 
 // SplitArgs is used to split a string to an array of arguments with separators: "(quotes) and spaces
-// We cant use strings.split as it includes empty string if deliminator is the last character in input string
+// A quoted string is a single argument, so "In review / QA" yields one argument.
+// Backslash escapes a quote, a space or another backslash so it is taken literally, both inside and outside quotes.
+// Any other backslash is kept as-is. Empty arguments are dropped.
 func SplitArgs(s string) ([]string, error) {
-	indexes := regexp.MustCompile("\"").FindAllStringIndex(s, -1)
-	if len(indexes)%2 != 0 {
-		return []string{}, errors.New("quotes not closed")
-	}
+	args, _, err := SplitArgsN(s, -1)
+	return args, err
+}
 
-	indexes = append([][]int{{0, 0}}, indexes...)
+// SplitArgsN splits the first n arguments of s the same way as SplitArgs and returns the rest of s untouched,
+// without leading spaces. A negative or zero n splits all arguments, leaving no remainder.
+func SplitArgsN(s string, n int) ([]string, string, error) {
+	var args []string
+	var current strings.Builder
+	quoted := false
 
-	if indexes[len(indexes)-1][1] < len(s) {
-		indexes = append(indexes, [][]int{{len(s), 0}}...)
+	flush := func() {
+		if current.Len() > 0 {
+			args = append(args, current.String())
+			current.Reset()
+		}
 	}
 
-	var args []string
-	for i := 0; i < len(indexes)-1; i++ {
-		start := indexes[i][1]
-		end := Min(len(s), indexes[i+1][0])
-
-		if i%2 == 0 {
-			args = append(args, strings.Split(strings.Trim(s[start:end], " "), " ")...)
-		} else {
-			args = append(args, s[start:end])
+	i := 0
+scan:
+	for ; i < len(s); i++ {
+		if n > 0 && len(args) == n {
+			break
+		}
+
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isEscapable(s[i+1]):
+			i++
+			current.WriteByte(s[i])
+		case c == '"':
+			// a quote always ends the current argument, whether it opens or closes a quoted string
+			flush()
+			if !quoted && n > 0 && len(args) == n {
+				// the quote opens the remainder, which is left untouched
+				break scan
+			}
+			quoted = !quoted
+		case c == ' ' && !quoted:
+			flush()
+		default:
+			current.WriteByte(c)
 		}
+	}
 
+	if quoted {
+		return []string{}, "", errors.New("quotes not closed")
 	}
 
-	cleanedArgs := make([]string, len(args))
-	count := 0
+	flush()
+	return args, strings.TrimLeft(s[i:], " "), nil
+}
+
+// TrimEnclosingQuotes strips the one pair of quotes enclosing s when all of s is a single quoted argument,
+// as a free-text argument may be quoted out of habit. The text inside the quotes is returned exactly as typed.
+func TrimEnclosingQuotes(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
 
-	for _, arg := range args {
-		if arg != "" {
-			cleanedArgs[count] = arg
-			count++
+	// the opening quote must only be closed by the last one, escapes being skipped as in SplitArgs
+	for i := 1; i < len(s)-1; i++ {
+		switch {
+		case s[i] == '\\' && isEscapable(s[i+1]):
+			if i+1 == len(s)-1 {
+				// the last quote is escaped, so it doesn't close the opening one
+				return s
+			}
+			i++
+		case s[i] == '"':
+			return s
 		}
 	}
+	return s[1 : len(s)-1]
+}
 
-	return cleanedArgs[0:count], nil
+func isEscapable(c byte) bool {
+	return c == '"' || c == ' ' || c == '\\'
 }
 
 // Min is used here as math.Min is for floats and casting to and from floats is dangerous.
@@ -0,0 +1,98 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "spaces", input: "/techbot subscribe python java", want: []string{"/techbot", "subscribe", "python", "java"}},
+		{name: "repeated and surrounding spaces", input: "  a   b  ", want: []string{"a", "b"}},
+		{name: "quoted group", input: `a "In review / QA" b`, want: []string{"a", "In review / QA", "b"}},
+		{name: "quote ends the current argument", input: `abc"def ghi"jkl`, want: []string{"abc", "def ghi", "jkl"}},
+		{name: "empty args dropped", input: `a "" b`, want: []string{"a", "b"}},
+		{name: "empty input", input: "", want: nil},
+		{name: "unclosed quote", input: `a "b c`, want: []string{}, wantErr: true},
+		{name: "escaped quote", input: `say \"hi\"`, want: []string{"say", `"hi"`}},
+		{name: "escaped quote inside quotes", input: `"a \"b\" c"`, want: []string{`a "b" c`}},
+		{name: "escaped space", input: `a\ b c`, want: []string{"a b", "c"}},
+		{name: "escaped backslash", input: `a\\ b`, want: []string{`a\`, "b"}},
+		{name: "other backslashes kept", input: `C:\path \d+`, want: []string{`C:\path`, `\d+`}},
+		{name: "lone trailing backslash", input: `a b\`, want: []string{"a", `b\`}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := SplitArgs(test.input)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("SplitArgs(%q) error = %v, wantErr %v", test.input, err, test.wantErr)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("SplitArgs(%q) = %q, want %q", test.input, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSplitArgsN(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		n             int
+		want          []string
+		wantRemainder string
+		wantErr       bool
+	}{
+		{name: "remainder kept as typed", input: `python what  does "nil" mean`, n: 1, want: []string{"python"}, wantRemainder: `what  does "nil" mean`},
+		{name: "unbalanced quote in remainder", input: `python is 5" enough`, n: 1, want: []string{"python"}, wantRemainder: `is 5" enough`},
+		{name: "backslashes in remainder", input: `python \\d+ \\server\share`, n: 1, want: []string{"python"}, wantRemainder: `\\d+ \\server\share`},
+		{name: "quoted argument before remainder", input: `"py thon" x`, n: 1, want: []string{"py thon"}, wantRemainder: "x"},
+		{name: "remainder starting with a quote", input: `a"b c"`, n: 1, want: []string{"a"}, wantRemainder: `"b c"`},
+		{name: "fewer arguments than n", input: "a", n: 2, want: []string{"a"}, wantRemainder: ""},
+		{name: "zero splits all", input: "a b c", n: 0, want: []string{"a", "b", "c"}, wantRemainder: ""},
+		{name: "unclosed quote before n", input: `"a b`, n: 1, want: []string{}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, remainder, err := SplitArgsN(test.input, test.n)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("SplitArgsN(%q, %d) error = %v, wantErr %v", test.input, test.n, err, test.wantErr)
+			}
+			if !reflect.DeepEqual(got, test.want) || remainder != test.wantRemainder {
+				t.Errorf("SplitArgsN(%q, %d) = %q, %q, want %q, %q", test.input, test.n, got, remainder, test.want, test.wantRemainder)
+			}
+		})
+	}
+}
+
+func TestTrimEnclosingQuotes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "quoted", input: `"what does nil mean"`, want: "what does nil mean"},
+		{name: "escaped quotes kept as typed", input: `"what does \"nil\" mean"`, want: `what does \"nil\" mean`},
+		{name: "empty quotes", input: `""`, want: ""},
+		{name: "unquoted", input: `what does "nil" mean`, want: `what does "nil" mean`},
+		{name: "several quoted arguments", input: `"what" "nil"`, want: `"what" "nil"`},
+		{name: "empty quoted argument first", input: `"" "b"`, want: `"" "b"`},
+		{name: "escaped closing quote", input: `"what\"`, want: `"what\"`},
+		{name: "lone quote", input: `"`, want: `"`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := TrimEnclosingQuotes(test.input); got != test.want {
+				t.Errorf("TrimEnclosingQuotes(%q) = %q, want %q", test.input, got, test.want)
+			}
+		})
+	}
+}